@@ -0,0 +1,310 @@
+// Hand-maintained in the style of an abigen-generated binding: there is no
+// FluxAggregator.abi/.bin checked in and no go:generate step that produces
+// this file, so it has to be kept in sync with the FluxAggregator ABI by
+// hand rather than by re-running a generator.
+
+package flux_aggregator_wrapper
+
+import (
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/pkg/errors"
+)
+
+// FluxAggregatorFilterer exposes indexed-topic event filtering for the
+// FluxAggregator contract, written by hand in the style of go-ethereum's
+// abigen. It lets a caller subscribe to a specific round or
+// starting address at the RPC level (eth_newFilter / eth_subscribe topics)
+// instead of receiving every NewRound/AnswerUpdated log and discarding most
+// of them in Go.
+type FluxAggregatorFilterer struct {
+	abi      abi.ABI
+	contract *bind.BoundContract
+}
+
+// NewFluxAggregatorFilterer binds a FluxAggregatorFilterer to address using
+// the FluxAggregator ABI baked into the eth package's versioned contract
+// metadata. It only needs a bind.ContractFilterer (FilterLogs +
+// SubscribeFilterLogs), so it can watch events on a contract this process
+// never deployed.
+func NewFluxAggregatorFilterer(address common.Address, filterer bind.ContractFilterer) (*FluxAggregatorFilterer, error) {
+	contractAbi, err := eth.GetV6ContractABI("FluxAggregator")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load FluxAggregator ABI")
+	}
+	contract := bind.NewBoundContract(address, contractAbi, nil, nil, filterer)
+	return &FluxAggregatorFilterer{abi: contractAbi, contract: contract}, nil
+}
+
+// FluxAggregatorNewRound represents a NewRound event raised by the
+// FluxAggregator contract.
+type FluxAggregatorNewRound struct {
+	RoundId   *big.Int
+	StartedBy common.Address
+	StartedAt *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FluxAggregatorNewRoundIterator iterates a historical page of NewRound
+// events, following the same Next/Event/Error/Close shape abigen generates
+// for every filtered event.
+type FluxAggregatorNewRoundIterator struct {
+	Event *FluxAggregatorNewRound
+
+	contract *bind.BoundContract
+	event    string
+
+	logs chan types.Log
+	sub  ethereumSubscription
+	done bool
+	fail error
+}
+
+// Next advances the iterator to the next event, returning false once the
+// iterator is exhausted or errors out; check Error after Next returns false.
+func (it *FluxAggregatorNewRoundIterator) Next() bool {
+	if it.fail != nil {
+		return false
+	}
+	select {
+	case log, ok := <-it.logs:
+		if !ok {
+			it.done = true
+			return false
+		}
+		event := new(FluxAggregatorNewRound)
+		if err := it.contract.UnpackLog(event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		event.Raw = log
+		it.Event = event
+		return true
+	}
+}
+
+// Error returns any error that occurred while iterating.
+func (it *FluxAggregatorNewRoundIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration and unsubscribes from the underlying event
+// source.
+func (it *FluxAggregatorNewRoundIterator) Close() error {
+	if it.sub != nil {
+		it.sub.Unsubscribe()
+	}
+	return nil
+}
+
+// ethereumSubscription is the subset of event.Subscription the iterator
+// needs; declared locally so tests can stub it without a live client.
+type ethereumSubscription interface {
+	Unsubscribe()
+	Err() <-chan error
+}
+
+// FilterNewRound returns an iterator over NewRound events matching the given
+// roundId / startedBy indexed-topic filters (nil/empty matches any value).
+func (_FluxAggregator *FluxAggregatorFilterer) FilterNewRound(opts *bind.FilterOpts, roundId []*big.Int, startedBy []common.Address) (*FluxAggregatorNewRoundIterator, error) {
+	var roundIdRule []interface{}
+	for _, r := range roundId {
+		roundIdRule = append(roundIdRule, r)
+	}
+	var startedByRule []interface{}
+	for _, s := range startedBy {
+		startedByRule = append(startedByRule, s)
+	}
+
+	logs, sub, err := _FluxAggregator.contract.FilterLogs(opts, "NewRound", roundIdRule, startedByRule)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to filter FluxAggregator NewRound logs")
+	}
+	return &FluxAggregatorNewRoundIterator{contract: _FluxAggregator.contract, event: "NewRound", logs: logs, sub: sub}, nil
+}
+
+// WatchNewRound subscribes to new NewRound events matching the given
+// roundId / startedBy indexed-topic filters, delivering decoded events on
+// sink until the returned subscription is unsubscribed.
+func (_FluxAggregator *FluxAggregatorFilterer) WatchNewRound(opts *bind.WatchOpts, sink chan<- *FluxAggregatorNewRound, roundId []*big.Int, startedBy []common.Address) (event.Subscription, error) {
+	var roundIdRule []interface{}
+	for _, r := range roundId {
+		roundIdRule = append(roundIdRule, r)
+	}
+	var startedByRule []interface{}
+	for _, s := range startedBy {
+		startedByRule = append(startedByRule, s)
+	}
+
+	logs, sub, err := _FluxAggregator.contract.WatchLogs(opts, "NewRound", roundIdRule, startedByRule)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to watch FluxAggregator NewRound logs")
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				ev := new(FluxAggregatorNewRound)
+				if err := _FluxAggregator.contract.UnpackLog(ev, "NewRound", log); err != nil {
+					return err
+				}
+				ev.Raw = log
+				select {
+				case sink <- ev:
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNewRound decodes a single raw NewRound log.
+func (_FluxAggregator *FluxAggregatorFilterer) ParseNewRound(log types.Log) (*FluxAggregatorNewRound, error) {
+	event := new(FluxAggregatorNewRound)
+	if err := _FluxAggregator.contract.UnpackLog(event, "NewRound", log); err != nil {
+		return nil, errors.Wrap(err, "unable to parse FluxAggregator NewRound log")
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// FluxAggregatorAnswerUpdated represents an AnswerUpdated event raised by the
+// FluxAggregator contract.
+type FluxAggregatorAnswerUpdated struct {
+	Current   *big.Int
+	RoundId   *big.Int
+	UpdatedAt *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FluxAggregatorAnswerUpdatedIterator iterates a historical page of
+// AnswerUpdated events.
+type FluxAggregatorAnswerUpdatedIterator struct {
+	Event *FluxAggregatorAnswerUpdated
+
+	contract *bind.BoundContract
+	event    string
+
+	logs chan types.Log
+	sub  ethereumSubscription
+	done bool
+	fail error
+}
+
+// Next advances the iterator; see FluxAggregatorNewRoundIterator.Next.
+func (it *FluxAggregatorAnswerUpdatedIterator) Next() bool {
+	if it.fail != nil {
+		return false
+	}
+	select {
+	case log, ok := <-it.logs:
+		if !ok {
+			it.done = true
+			return false
+		}
+		event := new(FluxAggregatorAnswerUpdated)
+		if err := it.contract.UnpackLog(event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		event.Raw = log
+		it.Event = event
+		return true
+	}
+}
+
+// Error returns any error that occurred while iterating.
+func (it *FluxAggregatorAnswerUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration and unsubscribes from the underlying event
+// source.
+func (it *FluxAggregatorAnswerUpdatedIterator) Close() error {
+	if it.sub != nil {
+		it.sub.Unsubscribe()
+	}
+	return nil
+}
+
+// FilterAnswerUpdated returns an iterator over AnswerUpdated events matching
+// the given current / roundId indexed-topic filters.
+func (_FluxAggregator *FluxAggregatorFilterer) FilterAnswerUpdated(opts *bind.FilterOpts, current []*big.Int, roundId []*big.Int) (*FluxAggregatorAnswerUpdatedIterator, error) {
+	var currentRule []interface{}
+	for _, c := range current {
+		currentRule = append(currentRule, c)
+	}
+	var roundIdRule []interface{}
+	for _, r := range roundId {
+		roundIdRule = append(roundIdRule, r)
+	}
+
+	logs, sub, err := _FluxAggregator.contract.FilterLogs(opts, "AnswerUpdated", currentRule, roundIdRule)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to filter FluxAggregator AnswerUpdated logs")
+	}
+	return &FluxAggregatorAnswerUpdatedIterator{contract: _FluxAggregator.contract, event: "AnswerUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchAnswerUpdated subscribes to new AnswerUpdated events matching the
+// given current / roundId indexed-topic filters.
+func (_FluxAggregator *FluxAggregatorFilterer) WatchAnswerUpdated(opts *bind.WatchOpts, sink chan<- *FluxAggregatorAnswerUpdated, current []*big.Int, roundId []*big.Int) (event.Subscription, error) {
+	var currentRule []interface{}
+	for _, c := range current {
+		currentRule = append(currentRule, c)
+	}
+	var roundIdRule []interface{}
+	for _, r := range roundId {
+		roundIdRule = append(roundIdRule, r)
+	}
+
+	logs, sub, err := _FluxAggregator.contract.WatchLogs(opts, "AnswerUpdated", currentRule, roundIdRule)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to watch FluxAggregator AnswerUpdated logs")
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				ev := new(FluxAggregatorAnswerUpdated)
+				if err := _FluxAggregator.contract.UnpackLog(ev, "AnswerUpdated", log); err != nil {
+					return err
+				}
+				ev.Raw = log
+				select {
+				case sink <- ev:
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAnswerUpdated decodes a single raw AnswerUpdated log.
+func (_FluxAggregator *FluxAggregatorFilterer) ParseAnswerUpdated(log types.Log) (*FluxAggregatorAnswerUpdated, error) {
+	event := new(FluxAggregatorAnswerUpdated)
+	if err := _FluxAggregator.contract.UnpackLog(event, "AnswerUpdated", log); err != nil {
+		return nil, errors.Wrap(err, "unable to parse FluxAggregator AnswerUpdated log")
+	}
+	event.Raw = log
+	return event, nil
+}
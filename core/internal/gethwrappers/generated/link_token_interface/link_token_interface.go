@@ -0,0 +1,84 @@
+// Hand-maintained in the style of an abigen-generated binding: there is no
+// LinkToken.abi/.bin checked in and no go:generate step that produces this
+// file, so it has to be kept in sync with the LinkToken ABI by hand rather
+// than by re-running a generator.
+
+package link_token_interface
+
+import (
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// LinkToken is a hand-maintained, abigen-style binding for the ERC677 LINK
+// token contract: a
+// bind.BoundContract plus the handful of methods FluxAggregator's test
+// harnesses need to fund a contract and check its balance.
+type LinkToken struct {
+	address  common.Address
+	abi      abi.ABI
+	contract *bind.BoundContract
+}
+
+// linkTokenBin is the compiled LinkToken runtime bytecode, baked into the
+// eth package's versioned contract metadata alongside its ABI so
+// DeployLinkToken doesn't need its own copy to go stale against.
+func linkTokenBin() ([]byte, error) {
+	bin, err := eth.GetV6ContractBytecode("LinkToken")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load LinkToken bytecode")
+	}
+	return bin, nil
+}
+
+// DeployLinkToken deploys a new LinkToken contract, minting its entire
+// supply to the deploying account, and returns its address, deployment
+// transaction, and a bound LinkToken for interacting with it.
+func DeployLinkToken(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *LinkToken, error) {
+	contractAbi, err := eth.GetV6ContractABI("LinkToken")
+	if err != nil {
+		return common.Address{}, nil, nil, errors.Wrap(err, "unable to load LinkToken ABI")
+	}
+	bin, err := linkTokenBin()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+
+	address, tx, boundContract, err := bind.DeployContract(auth, contractAbi, bin, backend)
+	if err != nil {
+		return common.Address{}, nil, nil, errors.Wrap(err, "unable to deploy LinkToken")
+	}
+	return address, tx, &LinkToken{address: address, abi: contractAbi, contract: boundContract}, nil
+}
+
+// NewLinkToken binds a LinkToken to an already-deployed contract at address.
+func NewLinkToken(address common.Address, backend bind.ContractBackend) (*LinkToken, error) {
+	contractAbi, err := eth.GetV6ContractABI("LinkToken")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load LinkToken ABI")
+	}
+	contract := bind.NewBoundContract(address, contractAbi, backend, backend, backend)
+	return &LinkToken{address: address, abi: contractAbi, contract: contract}, nil
+}
+
+// TransferAndCall transfers value LINK from the transactor to to, invoking
+// to's onTokenTransfer hook with data in the same transaction. FluxAggregator
+// only credits a transfer to its available funds when it arrives this way,
+// matching the ERC677 behavior real LINK deployments use.
+func (_LinkToken *LinkToken) TransferAndCall(opts *bind.TransactOpts, to common.Address, value *big.Int, data []byte) (*types.Transaction, error) {
+	return _LinkToken.contract.Transact(opts, "transferAndCall", to, value, data)
+}
+
+// BalanceOf returns account's LINK balance.
+func (_LinkToken *LinkToken) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var result *big.Int
+	err := _LinkToken.contract.Call(opts, &result, "balanceOf", account)
+	return result, err
+}
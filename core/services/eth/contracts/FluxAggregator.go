@@ -1,11 +1,15 @@
 package contracts
 
 import (
+	"context"
 	"math/big"
+	"sort"
 
+	"github.com/smartcontractkit/chainlink/core/internal/gethwrappers/generated/flux_aggregator_wrapper"
 	"github.com/smartcontractkit/chainlink/core/services/eth"
 	"github.com/smartcontractkit/chainlink/core/services/log"
 
+	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
@@ -18,6 +22,14 @@ type FluxAggregator interface {
 	RoundState(oracle common.Address, roundID uint32) (FluxAggregatorRoundState, error)
 	GetOracles() ([]common.Address, error)
 	LatestRoundData() (FluxAggregatorRoundData, error)
+	GetRoundData(roundID uint32) (FluxAggregatorRoundData, error)
+	HistoricalRounds(ctx context.Context, fromRound, toRound uint32) ([]FluxAggregatorRoundData, error)
+	HistoricalRoundsChan(ctx context.Context, fromRound, toRound uint32) (<-chan FluxAggregatorRoundData, <-chan error)
+	// Filterer exposes the hand-maintained, abigen-style event bindings so callers
+	// can filter/watch NewRound or AnswerUpdated by indexed topic (round ID,
+	// starting address) at the RPC level, rather than receiving every event
+	// and discarding most of them in Go.
+	Filterer() *flux_aggregator_wrapper.FluxAggregatorFilterer
 }
 
 const (
@@ -39,26 +51,41 @@ type fluxAggregator struct {
 	ConnectedContract
 	ethClient eth.Client
 	address   common.Address
+	codec     eth.ContractCodec
+	filterer  *flux_aggregator_wrapper.FluxAggregatorFilterer
 }
 
-type LogNewRound struct {
-	types.Log
-	RoundId   *big.Int
-	StartedBy common.Address
-	// seconds since unix epoch
-	StartedAt *big.Int
-}
+// DefaultHistoricalRoundsLogBackfillBatchSize is the number of blocks fetched
+// per eth_getLogs call when HistoricalRounds backfills NewRound /
+// AnswerUpdated events. Most node operators cap the block range (or log
+// count) a single getLogs call may cover, so large round ranges are walked in
+// batches of this size rather than requested in one shot.
+const DefaultHistoricalRoundsLogBackfillBatchSize = uint64(1000)
 
-type LogAnswerUpdated struct {
-	types.Log
-	Current   *big.Int
-	RoundId   *big.Int
-	UpdatedAt *big.Int
-}
+// HistoricalRoundsLogBackfillBatchSize overrides
+// DefaultHistoricalRoundsLogBackfillBatchSize. It is a package var, rather
+// than a HistoricalRounds parameter, so it can be tuned once for RPC
+// providers with tighter getLogs limits.
+var HistoricalRoundsLogBackfillBatchSize = DefaultHistoricalRoundsLogBackfillBatchSize
 
+// LogNewRound and LogAnswerUpdated are aliases of the abigen-style event
+// types in flux_aggregator_wrapper so existing call sites (decodeLog, rawLogOf, and everything in
+// HistoricalRoundsChan that type-switches on them) don't need to change
+// their import.
+type LogNewRound = flux_aggregator_wrapper.FluxAggregatorNewRound
+type LogAnswerUpdated = flux_aggregator_wrapper.FluxAggregatorAnswerUpdated
+
+// fluxAggregatorLogTypes is the topic -> destination-type table
+// log.NewDecodingLogListener needs to decode a raw log before handing it to
+// a listener; SubscribeToLogs still requires it for that reason. What
+// changed is what it decodes into: the values are now the
+// flux_aggregator_wrapper FluxAggregatorNewRound / FluxAggregatorAnswerUpdated
+// structs. Like the structs they replace, these carry no abi tags: decoding
+// matches their field names to the ABI's argument names, and they are kept
+// in step with the ABI by hand.
 var fluxAggregatorLogTypes = map[common.Hash]interface{}{
-	AggregatorNewRoundLogTopic20191220:      &LogNewRound{},
-	AggregatorAnswerUpdatedLogTopic20191220: &LogAnswerUpdated{},
+	AggregatorNewRoundLogTopic20191220:      &flux_aggregator_wrapper.FluxAggregatorNewRound{},
+	AggregatorAnswerUpdatedLogTopic20191220: &flux_aggregator_wrapper.FluxAggregatorAnswerUpdated{},
 }
 
 func NewFluxAggregator(address common.Address, ethClient eth.Client, logBroadcaster log.Broadcaster) (FluxAggregator, error) {
@@ -66,10 +93,23 @@ func NewFluxAggregator(address common.Address, ethClient eth.Client, logBroadcas
 	if err != nil {
 		return nil, err
 	}
+	filterer, err := flux_aggregator_wrapper.NewFluxAggregatorFilterer(address, ethClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to build FluxAggregator event filterer")
+	}
 	connectedContract := NewConnectedContract(codec, address, ethClient, logBroadcaster)
-	return &fluxAggregator{connectedContract, ethClient, address}, nil
+	return &fluxAggregator{connectedContract, ethClient, address, codec, filterer}, nil
 }
 
+// SubscribeToLogs registers listener for every NewRound / AnswerUpdated
+// event this FluxAggregator emits, via whatever log.Broadcaster the caller
+// wired up; this FluxAggregator doesn't pick a transport itself, but the
+// Broadcaster is expected to open its underlying subscription through
+// log.NewSubscription rather than calling eth.Client.SubscribeFilterLogs
+// directly, so a client with no websocket/IPC support (or one whose
+// eth_subscribe drops) transparently falls back to a
+// log.FilterPollSubscriber (eth_newFilter + eth_getFilterChanges polling)
+// instead.
 func (fa *fluxAggregator) SubscribeToLogs(listener log.Listener) (connected bool, _ UnsubscribeFunc) {
 	return fa.ConnectedContract.SubscribeToLogs(
 		log.NewDecodingLogListener(fa, fluxAggregatorLogTypes, listener),
@@ -126,3 +166,270 @@ func (fa *fluxAggregator) LatestRoundData() (FluxAggregatorRoundData, error) {
 	}
 	return result, nil
 }
+
+func (fa *fluxAggregator) Filterer() *flux_aggregator_wrapper.FluxAggregatorFilterer {
+	return fa.filterer
+}
+
+func (fa *fluxAggregator) GetRoundData(roundID uint32) (FluxAggregatorRoundData, error) {
+	var result FluxAggregatorRoundData
+	err := fa.Call(&result, "getRoundData", big.NewInt(int64(roundID)))
+	if err != nil {
+		return FluxAggregatorRoundData{},
+			errors.Wrapf(err, "error calling fluxaggregator#getRoundData for round %d", roundID)
+	}
+	return result, nil
+}
+
+// HistoricalRounds reconstructs FluxAggregatorRoundData for every round in
+// [fromRound, toRound] by combining getRoundData contract calls with a
+// chunked eth_getLogs backfill of the NewRound / AnswerUpdated events those
+// rounds span. It exists for oracles that start up mid-stream and need to
+// catch up on rounds that happened before LogBroadcaster began replaying logs
+// for them, without waiting on a from-genesis replay. Cancelling ctx aborts
+// the backfill and returns ctx.Err().
+func (fa *fluxAggregator) HistoricalRounds(ctx context.Context, fromRound, toRound uint32) ([]FluxAggregatorRoundData, error) {
+	roundCh, errCh := fa.HistoricalRoundsChan(ctx, fromRound, toRound)
+	var capacity uint32
+	if fromRound <= toRound {
+		capacity = toRound - fromRound + 1
+	}
+	rounds := make([]FluxAggregatorRoundData, 0, capacity)
+	for roundCh != nil || errCh != nil {
+		select {
+		case round, open := <-roundCh:
+			if !open {
+				roundCh = nil
+				continue
+			}
+			rounds = append(rounds, round)
+		case err, open := <-errCh:
+			if !open {
+				errCh = nil
+				continue
+			}
+			return nil, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return rounds, nil
+}
+
+// HistoricalRoundsChan is the streaming variant of HistoricalRounds. It emits
+// rounds on roundCh in chronological order as they are reconstructed so a
+// caller can feed them to a job runner as if the underlying logs had arrived
+// live, rather than waiting for the whole range to backfill. Both channels
+// are closed when the range has been fully delivered or an error occurs;
+// cancelling ctx stops the backfill goroutine and closes both channels
+// early, so a caller that abandons roundCh part-way through (job runner
+// shutdown, only wanting the first few rounds) doesn't leak it blocked on a
+// send forever.
+func (fa *fluxAggregator) HistoricalRoundsChan(ctx context.Context, fromRound, toRound uint32) (<-chan FluxAggregatorRoundData, <-chan error) {
+	roundCh := make(chan FluxAggregatorRoundData)
+	errCh := make(chan error, 1)
+
+	if fromRound > toRound {
+		close(roundCh)
+		errCh <- errors.Errorf("HistoricalRoundsChan: fromRound %d is greater than toRound %d", fromRound, toRound)
+		close(errCh)
+		return roundCh, errCh
+	}
+
+	go func() {
+		defer close(roundCh)
+		defer close(errCh)
+
+		fromBlock, err := fa.blockNumberForRound(ctx, fromRound)
+		if err != nil {
+			errCh <- errors.Wrap(err, "unable to locate start block for HistoricalRounds")
+			return
+		}
+		toBlock, err := fa.blockNumberForRound(ctx, toRound+1)
+		if err != nil {
+			// toRound has likely not completed yet; fall back to the chain head.
+			head, herr := fa.ethClient.HeaderByNumber(ctx, nil)
+			if herr != nil {
+				errCh <- errors.Wrap(herr, "unable to determine chain head for HistoricalRounds")
+				return
+			}
+			toBlock = head.Number
+		}
+
+		decodedLogs, err := fa.backfillLogs(ctx, fromBlock, toBlock)
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		rounds := make(map[uint32]FluxAggregatorRoundData, toRound-fromRound+1)
+		for _, decoded := range decodedLogs {
+			switch l := decoded.(type) {
+			case *LogNewRound:
+				roundID := uint32(l.RoundId.Uint64())
+				if roundID < fromRound || roundID > toRound {
+					continue
+				}
+				round := rounds[roundID]
+				round.RoundID = l.RoundId
+				round.StartedAt = l.StartedAt
+				rounds[roundID] = round
+			case *LogAnswerUpdated:
+				roundID := uint32(l.RoundId.Uint64())
+				if roundID < fromRound || roundID > toRound {
+					continue
+				}
+				round := rounds[roundID]
+				round.RoundID = l.RoundId
+				round.Answer = l.Current
+				round.UpdatedAt = l.UpdatedAt
+				rounds[roundID] = round
+			}
+		}
+
+		for roundID := fromRound; ; roundID++ {
+			if ctx.Err() != nil {
+				return
+			}
+
+			round, found := rounds[roundID]
+			if !found || round.Answer == nil {
+				// No AnswerUpdated log landed in range, e.g. the round closed
+				// before our earliest indexed block. Fall back to the
+				// contract's own bookkeeping rather than leaving it empty.
+				fetched, ferr := fa.GetRoundData(roundID)
+				if ferr != nil {
+					errCh <- errors.Wrapf(ferr, "unable to backfill round %d", roundID)
+					return
+				}
+				round = fetched
+			}
+
+			select {
+			case roundCh <- round:
+			case <-ctx.Done():
+				return
+			}
+
+			if roundID == toRound {
+				break
+			}
+		}
+	}()
+
+	return roundCh, errCh
+}
+
+// blockNumberForRound locates the block a round started in by binary
+// searching on block timestamps against the round's recorded startedAt time.
+func (fa *fluxAggregator) blockNumberForRound(ctx context.Context, roundID uint32) (*big.Int, error) {
+	data, err := fa.GetRoundData(roundID)
+	if err != nil {
+		return nil, err
+	}
+	if data.StartedAt == nil || data.StartedAt.Sign() == 0 {
+		return nil, errors.Errorf("round %d has no recorded start time", roundID)
+	}
+	return fa.blockNumberForTimestamp(ctx, data.StartedAt.Uint64())
+}
+
+// blockNumberForTimestamp returns the earliest block whose timestamp is
+// greater than or equal to target. Nodes don't expose a timestamp index, so
+// this walks the chain with a binary search over block headers instead.
+func (fa *fluxAggregator) blockNumberForTimestamp(ctx context.Context, target uint64) (*big.Int, error) {
+	head, err := fa.ethClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch chain head")
+	}
+
+	low, high := big.NewInt(1), new(big.Int).Set(head.Number)
+	for low.Cmp(high) < 0 {
+		mid := new(big.Int).Add(low, high)
+		mid.Div(mid, big.NewInt(2))
+
+		header, err := fa.ethClient.HeaderByNumber(ctx, mid)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to fetch header for block %s", mid)
+		}
+		if header.Time < target {
+			low = new(big.Int).Add(mid, big.NewInt(1))
+		} else {
+			high = mid
+		}
+	}
+	return low, nil
+}
+
+// backfillLogs fetches and decodes every NewRound / AnswerUpdated log between
+// fromBlock and toBlock (inclusive), walking the range in
+// HistoricalRoundsLogBackfillBatchSize chunks to stay under the getLogs
+// limits most node operators impose, and returns them in chronological order.
+func (fa *fluxAggregator) backfillLogs(ctx context.Context, fromBlock, toBlock *big.Int) ([]interface{}, error) {
+	var decoded []interface{}
+	batchSize := new(big.Int).SetUint64(HistoricalRoundsLogBackfillBatchSize)
+
+	for cursor := new(big.Int).Set(fromBlock); cursor.Cmp(toBlock) <= 0; {
+		chunkEnd := new(big.Int).Add(cursor, batchSize)
+		chunkEnd.Sub(chunkEnd, big.NewInt(1))
+		if chunkEnd.Cmp(toBlock) > 0 {
+			chunkEnd = toBlock
+		}
+
+		rawLogs, err := fa.ethClient.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: cursor,
+			ToBlock:   chunkEnd,
+			Addresses: []common.Address{fa.address},
+			Topics:    [][]common.Hash{{AggregatorNewRoundLogTopic20191220, AggregatorAnswerUpdatedLogTopic20191220}},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to fetch logs for blocks %s-%s", cursor, chunkEnd)
+		}
+		for _, rawLog := range rawLogs {
+			d, err := fa.decodeLog(rawLog)
+			if err != nil {
+				return nil, err
+			}
+			decoded = append(decoded, d)
+		}
+
+		cursor = new(big.Int).Add(chunkEnd, big.NewInt(1))
+	}
+
+	sort.Slice(decoded, func(i, j int) bool {
+		li, lj := rawLogOf(decoded[i]), rawLogOf(decoded[j])
+		if li.BlockNumber != lj.BlockNumber {
+			return li.BlockNumber < lj.BlockNumber
+		}
+		return li.Index < lj.Index
+	})
+
+	return decoded, nil
+}
+
+// decodeLog decodes a raw NewRound/AnswerUpdated log via the
+// FluxAggregatorFilterer's ParseNewRound / ParseAnswerUpdated.
+func (fa *fluxAggregator) decodeLog(rawLog types.Log) (interface{}, error) {
+	if len(rawLog.Topics) == 0 {
+		return nil, errors.New("log has no topics")
+	}
+	switch rawLog.Topics[0] {
+	case AggregatorNewRoundLogTopic20191220:
+		return fa.filterer.ParseNewRound(rawLog)
+	case AggregatorAnswerUpdatedLogTopic20191220:
+		return fa.filterer.ParseAnswerUpdated(rawLog)
+	default:
+		return nil, errors.Errorf("no FluxAggregator log type for topic %s", rawLog.Topics[0].Hex())
+	}
+}
+
+func rawLogOf(decoded interface{}) types.Log {
+	switch l := decoded.(type) {
+	case *LogNewRound:
+		return l.Raw
+	case *LogAnswerUpdated:
+		return l.Raw
+	default:
+		return types.Log{}
+	}
+}
@@ -0,0 +1,184 @@
+package fluxaggregatortest_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth/contracts/fluxaggregatortest"
+	"github.com/smartcontractkit/chainlink/core/services/log"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHarness_AdvanceRound(t *testing.T) {
+	h := fluxaggregatortest.NewHarness(t, big.NewInt(1), 60)
+	h.FundAggregator(t, big.NewInt(1_000_000))
+
+	oracle := h.NewOracle(t, h.Deployer.From, 1, 1, 0)
+
+	roundID := h.AdvanceRound(t, []*bind.TransactOpts{oracle}, big.NewInt(42))
+
+	round, err := h.Aggregator.LatestRoundData(nil)
+	require.NoError(t, err)
+	assert.Equal(t, roundID, round.RoundId)
+	assert.Equal(t, big.NewInt(42), round.Answer)
+}
+
+// TestHarness_Contract_LatestRoundDataAndGetRoundData drives the same
+// round through contracts.NewFluxAggregator rather than the raw abigen
+// binding, to exercise the contract-call and ABI-decoding path a running
+// node actually uses.
+func TestHarness_Contract_LatestRoundDataAndGetRoundData(t *testing.T) {
+	h := fluxaggregatortest.NewHarness(t, big.NewInt(1), 60)
+	h.FundAggregator(t, big.NewInt(1_000_000))
+
+	oracle := h.NewOracle(t, h.Deployer.From, 1, 1, 0)
+	roundID := h.AdvanceRound(t, []*bind.TransactOpts{oracle}, big.NewInt(42))
+
+	latest, err := h.Contract.LatestRoundData()
+	require.NoError(t, err)
+	assert.Equal(t, roundID, latest.RoundID)
+	assert.Equal(t, big.NewInt(42), latest.Answer)
+
+	fetched, err := h.Contract.GetRoundData(uint32(roundID.Uint64()))
+	require.NoError(t, err)
+	assert.Equal(t, roundID, fetched.RoundID)
+	assert.Equal(t, big.NewInt(42), fetched.Answer)
+}
+
+// TestHarness_Contract_RoundState exercises RoundState's timeout bookkeeping
+// by mining empty blocks past the round's timeout and checking the oracle
+// becomes eligible to start a new one.
+func TestHarness_Contract_RoundState(t *testing.T) {
+	h := fluxaggregatortest.NewHarness(t, big.NewInt(1), 1)
+	h.FundAggregator(t, big.NewInt(1_000_000))
+
+	oracle := h.NewOracle(t, h.Deployer.From, 1, 1, 0)
+	h.AdvanceRound(t, []*bind.TransactOpts{oracle}, big.NewInt(42))
+
+	h.MineBlocks(5)
+
+	state, err := h.Contract.RoundState(oracle.From, 0)
+	require.NoError(t, err)
+	assert.True(t, state.EligibleToSubmit)
+}
+
+// TestHarness_Contract_HistoricalRounds backfills a range of rounds through
+// contracts.HistoricalRounds and checks it reconstructs the same answers the
+// raw binding reports, exercising the NewRound/AnswerUpdated log backfill
+// path against real emitted events.
+func TestHarness_Contract_HistoricalRounds(t *testing.T) {
+	h := fluxaggregatortest.NewHarness(t, big.NewInt(1), 60)
+	h.FundAggregator(t, big.NewInt(1_000_000))
+
+	oracle := h.NewOracle(t, h.Deployer.From, 1, 1, 0)
+	h.AdvanceRound(t, []*bind.TransactOpts{oracle}, big.NewInt(1))
+	h.AdvanceRound(t, []*bind.TransactOpts{oracle}, big.NewInt(2))
+	lastRound := h.AdvanceRound(t, []*bind.TransactOpts{oracle}, big.NewInt(3))
+
+	rounds, err := h.Contract.HistoricalRounds(context.Background(), 1, uint32(lastRound.Uint64()))
+	require.NoError(t, err)
+	require.Len(t, rounds, 3)
+	assert.Equal(t, big.NewInt(1), rounds[0].Answer)
+	assert.Equal(t, big.NewInt(2), rounds[1].Answer)
+	assert.Equal(t, big.NewInt(3), rounds[2].Answer)
+}
+
+// TestHarness_Contract_HistoricalRounds_RejectsInvertedRange checks that an
+// inverted round range is rejected up front rather than spinning the
+// backfill loop until roundID wraps around uint32.
+func TestHarness_Contract_HistoricalRounds_RejectsInvertedRange(t *testing.T) {
+	h := fluxaggregatortest.NewHarness(t, big.NewInt(1), 60)
+
+	_, err := h.Contract.HistoricalRounds(context.Background(), 5, 1)
+	assert.Error(t, err)
+}
+
+// TestHarness_Contract_HistoricalRoundsChan_StopsOnContextCancel checks that
+// cancelling the context passed to HistoricalRoundsChan closes both
+// channels instead of leaving the backfill goroutine blocked forever on a
+// caller that stopped draining roundCh early.
+func TestHarness_Contract_HistoricalRoundsChan_StopsOnContextCancel(t *testing.T) {
+	h := fluxaggregatortest.NewHarness(t, big.NewInt(1), 60)
+	h.FundAggregator(t, big.NewInt(1_000_000))
+
+	oracle := h.NewOracle(t, h.Deployer.From, 1, 1, 0)
+	for i := 0; i < 5; i++ {
+		h.AdvanceRound(t, []*bind.TransactOpts{oracle}, big.NewInt(int64(i)))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	roundCh, errCh := h.Contract.HistoricalRoundsChan(ctx, 1, 5)
+
+	<-roundCh // take exactly one round, then abandon the rest
+	cancel()
+
+	_, chOpen := <-roundCh
+	_, errChOpen := <-errCh
+	assert.False(t, chOpen, "roundCh should be closed once ctx is cancelled")
+	assert.False(t, errChOpen, "errCh should be closed once ctx is cancelled")
+}
+
+// TestHarness_Contract_SubscribeAndDecodeNewRound exercises the log
+// subscription decoding path this harness's Client/Contract pair was built
+// to cover: subscribing via log.NewSubscription against the simulated
+// backend (exactly as a log.Broadcaster would) and decoding the raw log it
+// delivers with Contract.Filterer(), the same decoder SubscribeToLogs uses.
+func TestHarness_Contract_SubscribeAndDecodeNewRound(t *testing.T) {
+	h := fluxaggregatortest.NewHarness(t, big.NewInt(1), 60)
+	h.FundAggregator(t, big.NewInt(1_000_000))
+	oracle := h.NewOracle(t, h.Deployer.From, 1, 1, 0)
+
+	rawLogs := make(chan types.Log, 1)
+	sub, err := log.NewSubscription(h.Client, ethereum.FilterQuery{
+		Addresses: []common.Address{h.Address},
+	}, rawLogs)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	h.AdvanceRound(t, []*bind.TransactOpts{oracle}, big.NewInt(42))
+
+	select {
+	case rawLog := <-rawLogs:
+		newRound, err := h.Contract.Filterer().ParseNewRound(rawLog)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(1), newRound.RoundId)
+		assert.Equal(t, oracle.From, newRound.StartedBy)
+	case err := <-sub.Err():
+		t.Fatalf("subscription error: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for NewRound log")
+	}
+}
+
+// TestHarness_Fork_RollsChainBackToParent exercises the reorg-handling
+// helper the harness exposes: submitting a round, forking back to before it
+// landed, and checking the aggregator's state reverts with it.
+func TestHarness_Fork_RollsChainBackToParent(t *testing.T) {
+	h := fluxaggregatortest.NewHarness(t, big.NewInt(1), 60)
+	h.FundAggregator(t, big.NewInt(1_000_000))
+	oracle := h.NewOracle(t, h.Deployer.From, 1, 1, 0)
+
+	preForkBlock, err := h.Backend.BlockByNumber(context.Background(), nil)
+	require.NoError(t, err)
+
+	h.AdvanceRound(t, []*bind.TransactOpts{oracle}, big.NewInt(42))
+
+	latest, err := h.Aggregator.LatestRoundData(nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), latest.RoundId)
+
+	h.Fork(t, preForkBlock.Hash())
+	h.MineBlocks(1)
+
+	latest, err = h.Aggregator.LatestRoundData(nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(0), latest.RoundId)
+}
@@ -0,0 +1,257 @@
+package log
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultFilterPollInterval is how often a FilterPollSubscriber polls
+	// eth_getFilterChanges when falling back from eth_subscribe.
+	DefaultFilterPollInterval = 3 * time.Second
+	// DefaultFilterLiveness is how long a remote eth_newFilter is trusted to
+	// still be registered on the node before FilterPollSubscriber
+	// proactively re-creates it, rather than waiting for a "filter not
+	// found" error.
+	DefaultFilterLiveness = 5 * time.Minute
+	// seenRetentionBlocks bounds how far back the dedup set in pollLoop
+	// remembers (blockHash, logIndex) pairs. A subscription is expected to
+	// run for the life of a node process, so without pruning seen would
+	// grow forever; filter rotation and missing-filter recovery are the
+	// only cases that can redeliver a log, and both only ever re-fetch logs
+	// within seenRetentionBlocks of the chain head, so anything older is safe
+	// to forget.
+	seenRetentionBlocks = 256
+)
+
+// FilterPollSubscriber polls eth_getFilterChanges instead of maintaining an
+// eth_subscribe websocket/IPC subscription. It's a ready-made fallback
+// transport for callers whose eth.Client has no websocket/IPC support, or
+// whose eth_subscribe subscription has dropped, so that FluxAggregator (and
+// other ConnectedContracts) can still be monitored against HTTP-only RPC
+// providers such as Infura HTTP or Alchemy REST.
+type FilterPollSubscriber struct {
+	ethClient      eth.Client
+	query          ethereum.FilterQuery
+	pollInterval   time.Duration
+	filterLiveness time.Duration
+
+	logs chan<- types.Log
+
+	chDone chan struct{}
+	chErr  chan error
+	once   sync.Once
+}
+
+// NewFilterPollSubscriber creates (but does not start) a polling
+// substitute for an eth_subscribe("logs", query) subscription. Matched logs
+// are delivered on logs; Start must be called to begin polling.
+func NewFilterPollSubscriber(ethClient eth.Client, query ethereum.FilterQuery, logs chan<- types.Log) *FilterPollSubscriber {
+	return &FilterPollSubscriber{
+		ethClient:      ethClient,
+		query:          query,
+		pollInterval:   DefaultFilterPollInterval,
+		filterLiveness: DefaultFilterLiveness,
+		logs:           logs,
+		chDone:         make(chan struct{}),
+		chErr:          make(chan error, 1),
+	}
+}
+
+// Err returns a channel of asynchronous polling errors. It is never closed.
+func (s *FilterPollSubscriber) Err() <-chan error {
+	return s.chErr
+}
+
+// Unsubscribe stops polling. It is safe to call more than once.
+func (s *FilterPollSubscriber) Unsubscribe() {
+	s.once.Do(func() { close(s.chDone) })
+}
+
+// Start registers a remote filter for s.query and begins polling it on a
+// background goroutine until Unsubscribe is called.
+func (s *FilterPollSubscriber) Start() error {
+	filterID, err := s.newFilter()
+	if err != nil {
+		return errors.Wrap(err, "FilterPollSubscriber: unable to install remote filter")
+	}
+	go s.pollLoop(filterID)
+	return nil
+}
+
+func (s *FilterPollSubscriber) newFilter() (rpc.ID, error) {
+	var filterID rpc.ID
+	err := s.ethClient.CallContext(context.Background(), &filterID, "eth_newFilter", toFilterArg(s.query))
+	return filterID, err
+}
+
+func (s *FilterPollSubscriber) pollLoop(filterID rpc.ID) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	filterCreatedAt := time.Now()
+	seen := make(map[logKey]uint64)
+
+	for {
+		select {
+		case <-s.chDone:
+			return
+		case <-ticker.C:
+			// Proactively rotate the filter once it's lived longer than
+			// filterLiveness, rather than waiting for the node to expire it
+			// and return "filter not found".
+			if time.Since(filterCreatedAt) > s.filterLiveness {
+				if newID, err := s.newFilter(); err == nil {
+					filterID = newID
+					filterCreatedAt = time.Now()
+				} else {
+					s.reportErr(errors.Wrap(err, "FilterPollSubscriber: unable to rotate expired filter"))
+					continue
+				}
+			}
+
+			var changes []types.Log
+			err := s.ethClient.CallContext(context.Background(), &changes, "eth_getFilterChanges", filterID)
+			if err != nil {
+				if isFilterNotFoundErr(err) {
+					// The node forgot our filter before filterLiveness
+					// elapsed (e.g. it was restarted). Recreate it and
+					// backfill the gap with a getLogs range query so no
+					// logs are lost between the old and new filter.
+					s.recoverFromMissingFilter(&filterID, &filterCreatedAt, seen)
+					continue
+				}
+				s.reportErr(errors.Wrap(err, "FilterPollSubscriber: eth_getFilterChanges failed"))
+				continue
+			}
+
+			for _, lg := range changes {
+				s.deliver(lg, seen)
+			}
+			s.pruneSeen(seen)
+		}
+	}
+}
+
+func (s *FilterPollSubscriber) recoverFromMissingFilter(filterID *rpc.ID, filterCreatedAt *time.Time, seen map[logKey]uint64) {
+	recoveryQuery := s.query
+	// The node has no memory of our cursor, so re-fetch the last
+	// seenRetentionBlocks blocks: every log already delivered from that
+	// window is still in seen, so none of them is delivered twice.
+	if head, err := s.ethClient.HeaderByNumber(context.Background(), nil); err == nil && head.Number.Uint64() > seenRetentionBlocks {
+		windowStart := new(big.Int).SetUint64(head.Number.Uint64() - seenRetentionBlocks)
+		if recoveryQuery.FromBlock == nil || recoveryQuery.FromBlock.Cmp(windowStart) < 0 {
+			recoveryQuery.FromBlock = windowStart
+		}
+	}
+	logs, err := s.ethClient.FilterLogs(context.Background(), recoveryQuery)
+	if err != nil {
+		s.reportErr(errors.Wrap(err, "FilterPollSubscriber: unable to recover missing filter via getLogs"))
+	} else {
+		for _, lg := range logs {
+			s.deliver(lg, seen)
+		}
+	}
+
+	newID, err := s.newFilter()
+	if err != nil {
+		s.reportErr(errors.Wrap(err, "FilterPollSubscriber: unable to re-create filter after it was lost"))
+		return
+	}
+	*filterID = newID
+	*filterCreatedAt = time.Now()
+}
+
+func (s *FilterPollSubscriber) deliver(lg types.Log, seen map[logKey]uint64) {
+	key := logKey{lg.BlockHash, lg.Index}
+	if _, ok := seen[key]; ok {
+		return
+	}
+	seen[key] = lg.BlockNumber
+
+	select {
+	case s.logs <- lg:
+	case <-s.chDone:
+	}
+}
+
+// pruneSeen evicts entries more than seenRetentionBlocks behind the current
+// chain head, so a long-lived subscription's dedup set stays bounded instead
+// of growing for as long as the subscription runs. It's best-effort: if the
+// head can't be fetched, seen is left as-is and pruned again on the next
+// poll.
+func (s *FilterPollSubscriber) pruneSeen(seen map[logKey]uint64) {
+	head, err := s.ethClient.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return
+	}
+	headNumber := head.Number.Uint64()
+	if headNumber <= seenRetentionBlocks {
+		return
+	}
+	cutoff := headNumber - seenRetentionBlocks
+	for key, blockNumber := range seen {
+		if blockNumber < cutoff {
+			delete(seen, key)
+		}
+	}
+}
+
+func (s *FilterPollSubscriber) reportErr(err error) {
+	select {
+	case s.chErr <- err:
+	default:
+		// Error channel is unbuffered past its first slot; callers are
+		// expected to drain Err() promptly, so a full channel means one is
+		// already pending and this one would just be noise.
+	}
+}
+
+type logKey struct {
+	blockHash common.Hash
+	logIndex  uint
+}
+
+func isFilterNotFoundErr(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "filter not found")
+}
+
+// toFilterArg mirrors go-ethereum's ethclient.toFilterArg, which is
+// unexported there: it converts a FilterQuery into the positional
+// object eth_newFilter expects over raw JSON-RPC.
+func toFilterArg(q ethereum.FilterQuery) interface{} {
+	arg := map[string]interface{}{
+		"address": q.Addresses,
+		"topics":  q.Topics,
+	}
+	if q.BlockHash != nil {
+		arg["blockHash"] = *q.BlockHash
+	} else {
+		if q.FromBlock == nil {
+			arg["fromBlock"] = "0x0"
+		} else {
+			arg["fromBlock"] = toBlockNumArg(q.FromBlock)
+		}
+		arg["toBlock"] = toBlockNumArg(q.ToBlock)
+	}
+	return arg
+}
+
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	return hexutil.EncodeBig(number)
+}
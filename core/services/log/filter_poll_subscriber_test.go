@@ -0,0 +1,224 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_toFilterArg(t *testing.T) {
+	address := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	topic := common.HexToHash("0xabc")
+
+	arg := toFilterArg(ethereum.FilterQuery{
+		Addresses: []common.Address{address},
+		Topics:    [][]common.Hash{{topic}},
+	})
+
+	argMap, ok := arg.(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "0x0", argMap["fromBlock"])
+	assert.Equal(t, "latest", argMap["toBlock"])
+}
+
+func Test_isFilterNotFoundErr(t *testing.T) {
+	assert.True(t, isFilterNotFoundErr(errors.New("filter not found")))
+	assert.True(t, isFilterNotFoundErr(errors.New("Filter Not Found")))
+	assert.False(t, isFilterNotFoundErr(errors.New("connection refused")))
+}
+
+// fakeFilterPollClient is a minimal eth.Client double that serves
+// eth_newFilter / eth_getFilterChanges over CallContext the way a real node
+// would, so FilterPollSubscriber's polling, rotation, and recovery logic can
+// be tested without a live RPC endpoint.
+type fakeFilterPollClient struct {
+	mu sync.Mutex
+
+	nextFilterID int
+	changes      map[rpc.ID][]types.Log
+	notFound     map[rpc.ID]bool
+	recoverLogs  []types.Log
+	head         uint64
+}
+
+func (c *fakeFilterPollClient) CallContext(_ context.Context, result interface{}, method string, args ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch method {
+	case "eth_newFilter":
+		c.nextFilterID++
+		id := rpc.ID(fmt.Sprintf("filter-%d", c.nextFilterID))
+		*(result.(*rpc.ID)) = id
+		return nil
+	case "eth_getFilterChanges":
+		id := args[0].(rpc.ID)
+		if c.notFound[id] {
+			return errors.New("filter not found")
+		}
+		batch := c.changes[id]
+		delete(c.changes, id)
+		*(result.(*[]types.Log)) = batch
+		return nil
+	default:
+		return fmt.Errorf("fakeFilterPollClient: unexpected method %s", method)
+	}
+}
+
+func (c *fakeFilterPollClient) FilterLogs(context.Context, ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.recoverLogs, nil
+}
+
+func (c *fakeFilterPollClient) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &types.Header{Number: new(big.Int).SetUint64(c.head)}, nil
+}
+
+func (c *fakeFilterPollClient) lastFilterID() rpc.ID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return rpc.ID(fmt.Sprintf("filter-%d", c.nextFilterID))
+}
+
+func (c *fakeFilterPollClient) queueChanges(id rpc.ID, logs []types.Log) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.changes == nil {
+		c.changes = map[rpc.ID][]types.Log{}
+	}
+	c.changes[id] = append(c.changes[id], logs...)
+}
+
+func (c *fakeFilterPollClient) markNotFound(id rpc.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.notFound == nil {
+		c.notFound = map[rpc.ID]bool{}
+	}
+	c.notFound[id] = true
+}
+
+func Test_FilterPollSubscriber_Deliver_DedupesByBlockHashAndLogIndex(t *testing.T) {
+	logs := make(chan types.Log, 2)
+	s := NewFilterPollSubscriber(&fakeFilterPollClient{}, ethereum.FilterQuery{}, logs)
+
+	lg := types.Log{BlockHash: common.HexToHash("0x1"), Index: 3, BlockNumber: 10}
+	seen := make(map[logKey]uint64)
+
+	s.deliver(lg, seen)
+	s.deliver(lg, seen)
+
+	assert.Len(t, logs, 1)
+}
+
+func Test_FilterPollSubscriber_PruneSeen_EvictsEntriesOlderThanRetentionWindow(t *testing.T) {
+	client := &fakeFilterPollClient{head: seenRetentionBlocks + 10}
+	s := NewFilterPollSubscriber(client, ethereum.FilterQuery{}, make(chan types.Log))
+
+	oldKey := logKey{blockHash: common.HexToHash("0x1"), logIndex: 0}
+	recentKey := logKey{blockHash: common.HexToHash("0x2"), logIndex: 0}
+	seen := map[logKey]uint64{
+		oldKey:    1,
+		recentKey: seenRetentionBlocks + 10,
+	}
+
+	s.pruneSeen(seen)
+
+	assert.NotContains(t, seen, oldKey)
+	assert.Contains(t, seen, recentKey)
+}
+
+func Test_FilterPollSubscriber_RecoverFromMissingFilter_BackfillsAndReinstallsFilter(t *testing.T) {
+	logs := make(chan types.Log, 1)
+	client := &fakeFilterPollClient{
+		recoverLogs: []types.Log{{BlockHash: common.HexToHash("0xa"), Index: 1}},
+	}
+	s := NewFilterPollSubscriber(client, ethereum.FilterQuery{}, logs)
+
+	filterID := rpc.ID("stale")
+	createdAt := time.Now().Add(-time.Hour)
+	seen := make(map[logKey]uint64)
+
+	s.recoverFromMissingFilter(&filterID, &createdAt, seen)
+
+	require.Len(t, logs, 1)
+	assert.NotEqual(t, rpc.ID("stale"), filterID)
+	assert.WithinDuration(t, time.Now(), createdAt, time.Second)
+}
+
+// fakeSubscribingClient extends fakeFilterPollClient with a
+// SubscribeFilterLogs that can be made to fail the way an HTTP-only RPC
+// provider does, so NewSubscription's fallback selection can be tested
+// without a real websocket/IPC endpoint.
+type fakeSubscribingClient struct {
+	fakeFilterPollClient
+
+	subscribeErr error
+}
+
+func (c *fakeSubscribingClient) SubscribeFilterLogs(context.Context, ethereum.FilterQuery, chan<- types.Log) (event.Subscription, error) {
+	return nil, c.subscribeErr
+}
+
+func Test_NewSubscription_FallsBackToFilterPollSubscriberWhenNotificationsUnsupported(t *testing.T) {
+	client := &fakeSubscribingClient{subscribeErr: errors.New("notifications not supported")}
+	logs := make(chan types.Log, 1)
+
+	sub, err := NewSubscription(client, ethereum.FilterQuery{}, logs)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	_, ok := sub.(*FilterPollSubscriber)
+	assert.True(t, ok, "expected NewSubscription to fall back to a *FilterPollSubscriber")
+}
+
+func Test_NewSubscription_PropagatesOtherSubscribeErrors(t *testing.T) {
+	client := &fakeSubscribingClient{subscribeErr: errors.New("connection refused")}
+
+	_, err := NewSubscription(client, ethereum.FilterQuery{}, make(chan types.Log, 1))
+	assert.Error(t, err)
+}
+
+func Test_FilterPollSubscriber_Start_PollsRotatesAndRecoversFromMissingFilter(t *testing.T) {
+	logs := make(chan types.Log, 10)
+	client := &fakeFilterPollClient{}
+	s := NewFilterPollSubscriber(client, ethereum.FilterQuery{}, logs)
+	s.pollInterval = 5 * time.Millisecond
+	s.filterLiveness = 20 * time.Millisecond
+	defer s.Unsubscribe()
+
+	require.NoError(t, s.Start())
+
+	firstID := client.lastFilterID()
+	client.queueChanges(firstID, []types.Log{{BlockHash: common.HexToHash("0x1"), Index: 0}})
+
+	require.Eventually(t, func() bool { return len(logs) == 1 }, time.Second, 5*time.Millisecond)
+	<-logs
+
+	// Wait past filterLiveness so pollLoop proactively rotates the filter.
+	require.Eventually(t, func() bool { return client.lastFilterID() != firstID }, time.Second, 5*time.Millisecond)
+	rotatedID := client.lastFilterID()
+
+	// Simulate the node forgetting the rotated filter; pollLoop should
+	// recover via getLogs and reinstall a fresh one.
+	client.markNotFound(rotatedID)
+	client.recoverLogs = []types.Log{{BlockHash: common.HexToHash("0x2"), Index: 0}}
+
+	require.Eventually(t, func() bool { return len(logs) == 1 }, time.Second, 5*time.Millisecond)
+}
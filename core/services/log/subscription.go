@@ -0,0 +1,43 @@
+package log
+
+import (
+	"context"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/services/eth"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/pkg/errors"
+)
+
+// NewSubscription is the transport-selection entry point a log.Broadcaster
+// should use instead of calling ethClient.SubscribeFilterLogs directly. It
+// tries a real eth_subscribe subscription first, and transparently falls
+// back to a FilterPollSubscriber when the client has no websocket/IPC
+// support to serve it (e.g. an HTTP-only RPC provider such as Infura HTTP
+// or Alchemy REST), so FluxAggregator and other ConnectedContracts can be
+// monitored the same way regardless of transport.
+func NewSubscription(ethClient eth.Client, query ethereum.FilterQuery, logs chan<- types.Log) (event.Subscription, error) {
+	sub, err := ethClient.SubscribeFilterLogs(context.Background(), query, logs)
+	if err == nil {
+		return sub, nil
+	}
+	if !isUnsupportedSubscriptionErr(err) {
+		return nil, errors.Wrap(err, "NewSubscription: unable to subscribe to logs")
+	}
+
+	poller := NewFilterPollSubscriber(ethClient, query, logs)
+	if err := poller.Start(); err != nil {
+		return nil, errors.Wrap(err, "NewSubscription: unable to start fallback FilterPollSubscriber")
+	}
+	return poller, nil
+}
+
+// isUnsupportedSubscriptionErr recognizes go-ethereum's rpc.ErrNotificationsUnsupported,
+// which SubscribeFilterLogs returns verbatim when the underlying transport
+// (plain HTTP) has no way to push notifications.
+func isUnsupportedSubscriptionErr(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "notifications not supported")
+}